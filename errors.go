@@ -0,0 +1,84 @@
+package goql
+
+import (
+	"strings"
+	"time"
+)
+
+// OnError selects how a stage reacts when one of its items fails, whether
+// from a panic or an explicit error returned by an E-variant predicate or
+// mapper.
+type OnError int
+
+const (
+	// ErrorAbort fails the whole query as soon as one item errors. This is
+	// the default, and matches the behavior of the pipeline before OnError
+	// existed.
+	ErrorAbort OnError = iota
+	// ErrorSkip drops the offending item and continues processing the rest
+	// of the query.
+	ErrorSkip
+	// ErrorCollect drops the offending item like ErrorSkip, but remembers
+	// the error. Wait returns every collected error together as a
+	// *MultiError once the query finishes.
+	ErrorCollect
+)
+
+// WithErrorPolicy sets how q reacts to a failing item. It defaults to
+// ErrorAbort.
+func (q *Queryable[TInput, TOutput]) WithErrorPolicy(policy OnError) *Queryable[TInput, TOutput] {
+	q.errorPolicy = policy
+	return q
+}
+
+// WithRetry retries a failing WhereE/SelectE/SelectManyE call on q up to
+// attempts times before treating it as a failure subject to q's OnError
+// policy. backoff is called with the attempt number (starting at 1) to
+// determine how long to sleep before the next attempt.
+func (q *Queryable[TInput, TOutput]) WithRetry(attempts int, backoff func(int) time.Duration) *Queryable[TInput, TOutput] {
+	q.retryAttempts = attempts
+	q.retryBackoff = backoff
+	return q
+}
+
+// handleItemError applies q's OnError policy to a single item's failure. It
+// returns the error to abort the query with, or nil if the item should
+// simply be dropped.
+func (q *Queryable[TInput, TOutput]) handleItemError(err error) error {
+	switch q.errorPolicy {
+	case ErrorSkip:
+		return nil
+	case ErrorCollect:
+		q.collectMu.Lock()
+		q.collectedErrs = append(q.collectedErrs, err)
+		q.collectMu.Unlock()
+		return nil
+	default:
+		return err
+	}
+}
+
+// collectedError returns the MultiError accumulated under ErrorCollect, or
+// nil if nothing was collected.
+func (q *Queryable[TInput, TOutput]) collectedError() *MultiError {
+	q.collectMu.Lock()
+	defer q.collectMu.Unlock()
+
+	if len(q.collectedErrs) == 0 {
+		return nil
+	}
+	return &MultiError{Errors: q.collectedErrs}
+}
+
+// MultiError aggregates the errors dropped by an ErrorCollect stage.
+type MultiError struct {
+	Errors []error
+}
+
+func (e *MultiError) Error() string {
+	messages := make([]string, len(e.Errors))
+	for i, err := range e.Errors {
+		messages[i] = err.Error()
+	}
+	return strings.Join(messages, "; ")
+}
@@ -0,0 +1,141 @@
+package goql
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQueryable_WhereE(t *testing.T) {
+	ctx := context.Background()
+	inputData := []int{1, 2, 3, 4, 5}
+	expectedData := []int{2, 4}
+
+	output, err :=
+		FromArray(inputData).
+			WithThreads(1).
+			WhereE(func(i int) (bool, error) { return i%2 == 0, nil }).
+			ToArray(ctx)
+
+	assert.Nil(t, err)
+	assert.Equal(t, expectedData, output)
+}
+
+func TestQueryable_WhereE_Error(t *testing.T) {
+	ctx := context.Background()
+	inputData := []int{1, 2, 3}
+
+	_, err :=
+		FromArray(inputData).
+			WithThreads(1).
+			WhereE(func(i int) (bool, error) {
+				if i == 2 {
+					return false, errors.Errorf("boom on %v", i)
+				}
+				return true, nil
+			}).
+			ToArray(ctx)
+
+	assert.NotNil(t, err)
+}
+
+func TestQueryable_SelectE(t *testing.T) {
+	ctx := context.Background()
+	inputData := []int{1, 2, 3}
+	expectedData := []int{2, 4, 6}
+
+	output, err :=
+		SelectE(
+			func(i int) (int, error) { return i * 2, nil },
+			FromArray(inputData).WithThreads(1)).
+			WithThreads(1).
+			ToArray(ctx)
+
+	assert.Nil(t, err)
+	assert.Equal(t, expectedData, output)
+}
+
+func TestQueryable_SelectManyE(t *testing.T) {
+	ctx := context.Background()
+	inputData := []int{1, 2}
+	expectedData := []int{1, -1, 2, -2}
+
+	output, err :=
+		SelectManyE(
+			func(i int) ([]int, error) { return []int{i, -i}, nil },
+			FromArray(inputData).WithThreads(1)).
+			WithThreads(1).
+			ToArray(ctx)
+
+	assert.Nil(t, err)
+	assert.Equal(t, expectedData, output)
+}
+
+func TestQueryable_SelectE_ErrorSkip(t *testing.T) {
+	ctx := context.Background()
+	inputData := []int{1, 2, 3, 4}
+	expectedData := []int{2, 4, 8}
+
+	output, err :=
+		SelectE(
+			func(i int) (int, error) {
+				if i == 3 {
+					return 0, errors.Errorf("cannot double %v", i)
+				}
+				return i * 2, nil
+			},
+			FromArray(inputData).WithThreads(1)).
+			WithErrorPolicy(ErrorSkip).
+			ToArray(ctx,
+				OrderAsc(func(i int) int { return i }))
+
+	assert.Nil(t, err)
+	assert.Equal(t, expectedData, output)
+}
+
+func TestQueryable_WithRetry_RecoversFromTransientErrors(t *testing.T) {
+	ctx := context.Background()
+	inputData := []int{1, 2, 3}
+	expectedData := []int{2, 4, 6}
+	attemptsByItem := map[int]int{}
+
+	output, err :=
+		SelectE(
+			func(i int) (int, error) {
+				attemptsByItem[i]++
+				if attemptsByItem[i] < 2 {
+					return 0, errors.Errorf("transient failure on %v", i)
+				}
+				return i * 2, nil
+			},
+			FromArray(inputData).WithThreads(1)).
+			WithRetry(2, func(int) time.Duration { return 0 }).
+			ToArray(ctx,
+				OrderAsc(func(i int) int { return i }))
+
+	assert.Nil(t, err)
+	assert.Equal(t, expectedData, output)
+}
+
+func TestQueryable_WithRetry_ExhaustedStillErrors(t *testing.T) {
+	ctx := context.Background()
+	inputData := []int{1}
+
+	_, err :=
+		SelectE(
+			func(i int) (int, error) { return 0, errors.Errorf("always fails") },
+			FromArray(inputData).WithThreads(1)).
+			WithRetry(2, func(int) time.Duration { return 0 }).
+			ToArray(ctx)
+
+	assert.NotNil(t, err)
+}
+
+func TestMultiError_Error(t *testing.T) {
+	err := &MultiError{Errors: []error{errors.Errorf("first"), errors.Errorf("second")}}
+
+	assert.Equal(t, "first; second", err.Error())
+}
@@ -0,0 +1,65 @@
+package goql
+
+import (
+	"context"
+	"runtime"
+)
+
+// Iterator pulls the next item from a lazy source, returning ok=false once the
+// source is exhausted.
+type Iterator[T any] func() (item T, ok bool)
+
+// Iterable is satisfied by anything that can hand out an Iterator, such as a
+// file reader, a DB cursor, or a paginated API client.
+type Iterable[T any] interface {
+	Iterate() Iterator[T]
+}
+
+// FromIterator builds a Queryable driven by a single producer goroutine that
+// pulls from next until it reports ok=false, respecting ctx.Done() along the
+// way. Use it to plug in lazy sources without buffering them into a slice or
+// managing a goroutine to fill a channel by hand.
+func FromIterator[T any](next Iterator[T]) *Queryable[T, T] {
+	return &Queryable[T, T]{
+		inputIter: next,
+		fnFilter:  []func(T) bool{},
+		fnMap:     func(item T) []T { return []T{item} },
+		pureMap:   true,
+		threads:   max(min(1, runtime.NumCPU()), 128),
+	}
+}
+
+// KeyValue pairs a map key with its value, as produced by FromMap.
+type KeyValue[K comparable, V any] struct {
+	Key   K
+	Value V
+}
+
+// FromMap adapts a map into a Queryable of its key/value pairs.
+func FromMap[K comparable, V any](m map[K]V) *Queryable[KeyValue[K, V], KeyValue[K, V]] {
+	pairs := make([]KeyValue[K, V], 0, len(m))
+	for k, v := range m {
+		pairs = append(pairs, KeyValue[K, V]{Key: k, Value: v})
+	}
+
+	return FromArray(pairs)
+}
+
+// ToIterator runs q and returns an Iterator pulling from its output. Callers
+// that need to observe pipeline errors should call q.Wait(ctx) once the
+// iterator reports ok=false.
+func (q *Queryable[TInput, TOutput]) ToIterator(ctx context.Context) Iterator[TOutput] {
+	outputChan := q.Run(ctx)
+
+	return func() (TOutput, bool) {
+		item, ok := <-outputChan
+		return item, ok
+	}
+}
+
+// Iterate satisfies Iterable, letting a Queryable itself be used as a lazy
+// source for another query. Pipeline errors are not observable through this
+// path; call ToIterator directly when you need q.Wait afterwards.
+func (q *Queryable[TInput, TOutput]) Iterate() Iterator[TOutput] {
+	return q.ToIterator(context.Background())
+}
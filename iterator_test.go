@@ -0,0 +1,63 @@
+package goql
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQueryable_FromIterator(t *testing.T) {
+	ctx := context.Background()
+	inputData := []int{1, 2, 3, 4, 5}
+	expectedData := []int{2, 4}
+
+	i := 0
+	next := func() (int, bool) {
+		if i >= len(inputData) {
+			return 0, false
+		}
+		item := inputData[i]
+		i++
+		return item, true
+	}
+
+	output, err :=
+		FromIterator(Iterator[int](next)).
+			WithThreads(1).
+			Where(func(i int) bool { return i%2 == 0 }).
+			ToArray(ctx)
+
+	assert.Nil(t, err)
+	assert.Equal(t, expectedData, output)
+}
+
+func TestQueryable_FromMap(t *testing.T) {
+	ctx := context.Background()
+	inputData := map[string]int{"a": 1, "b": 2}
+
+	output, err :=
+		FromMap(inputData).
+			ToArray(ctx, OrderAsc(func(kv KeyValue[string, int]) string { return kv.Key }))
+
+	assert.Nil(t, err)
+	assert.Equal(t, []KeyValue[string, int]{{Key: "a", Value: 1}, {Key: "b", Value: 2}}, output)
+}
+
+func TestQueryable_ToIterator(t *testing.T) {
+	ctx := context.Background()
+	inputData := []int{1, 2, 3}
+
+	next := FromArray(inputData).WithThreads(1).ToIterator(ctx)
+
+	output := []int{}
+	for {
+		item, ok := next()
+		if !ok {
+			break
+		}
+		output = append(output, item)
+	}
+
+	assert.Equal(t, inputData, output)
+}
@@ -0,0 +1,698 @@
+package goql
+
+import (
+	"context"
+	"runtime"
+	"sync"
+
+	"github.com/pkg/errors"
+	"golang.org/x/sync/errgroup"
+)
+
+// Distinct returns the distinct elements of q, in the order they are first seen.
+func Distinct[T comparable](q IQueryable[T]) *Queryable[T, T] {
+	return DistinctBy(func(item T) T { return item }, q)
+}
+
+// DistinctBy returns the elements of q whose key, as computed by keyGetter, has
+// not already been seen, in the order they are first seen.
+func DistinctBy[T any, K comparable](keyGetter func(T) K, q IQueryable[T]) *Queryable[T, T] {
+	var mu sync.Mutex
+	seen := map[K]struct{}{}
+
+	return &Queryable[T, T]{
+		parent:    q,
+		inputData: nil,
+		inputChan: nil,
+		fnFilter: []func(T) bool{
+			func(item T) bool {
+				key := keyGetter(item)
+
+				mu.Lock()
+				defer mu.Unlock()
+
+				if _, ok := seen[key]; ok {
+					return false
+				}
+				seen[key] = struct{}{}
+				return true
+			},
+		},
+		fnMap:   func(item T) []T { return []T{item} },
+		pureMap: true,
+		// A single worker keeps the first-seen order; the default worker pool
+		// would reshuffle it.
+		threads: 1,
+	}
+}
+
+// takeQueryable consumes its parent until shouldTake reports false, then cancels
+// the parent's context so upstream producers stop early instead of running to
+// completion. It is used by both Take and TakeWhile.
+type takeQueryable[T any] struct {
+	parent     IQueryable[T]
+	shouldTake func(T) bool
+	errorChan  chan error
+}
+
+func (q *takeQueryable[T]) Wait(ctx context.Context) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case err, ok := <-q.errorChan:
+		if !ok {
+			return nil
+		}
+		return err
+	}
+}
+
+func (q *takeQueryable[T]) Run(ctx context.Context) <-chan T {
+	eg, ctx := errgroup.WithContext(ctx)
+	innerCtx, cancel := context.WithCancel(ctx)
+
+	outputChan := make(chan T)
+	q.errorChan = make(chan error, 1)
+	inputChan := q.parent.Run(innerCtx)
+
+	stoppedEarly := make(chan struct{})
+
+	eg.Go(func() (err error) {
+		defer close(outputChan)
+		defer func() {
+			if r := recover(); r != nil {
+				err = errors.Errorf("panic while taking from query %v: %v", q, r)
+			}
+		}()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case data, ok := <-inputChan:
+				if !ok {
+					return nil
+				}
+				if !q.shouldTake(data) {
+					close(stoppedEarly)
+					cancel()
+					return nil
+				}
+				select {
+				case <-ctx.Done():
+					return ctx.Err()
+				case outputChan <- data:
+				}
+			}
+		}
+	})
+
+	eg.Go(func() error {
+		err := q.parent.Wait(innerCtx)
+		select {
+		case <-stoppedEarly:
+			if errors.Is(err, context.Canceled) {
+				return nil
+			}
+		default:
+		}
+		return err
+	})
+
+	go func() {
+		defer close(q.errorChan)
+		defer cancel()
+		if err := eg.Wait(); err != nil {
+			q.errorChan <- err
+		}
+	}()
+
+	return outputChan
+}
+
+// Take returns at most the first n elements of q. Once n elements have been
+// produced, the upstream query is cancelled rather than drained to completion.
+func (q *Queryable[TInput, TOutput]) Take(n int) *Queryable[TOutput, TOutput] {
+	remaining := n
+
+	return &Queryable[TOutput, TOutput]{
+		parent: &takeQueryable[TOutput]{
+			parent: q,
+			shouldTake: func(TOutput) bool {
+				if remaining <= 0 {
+					return false
+				}
+				remaining--
+				return true
+			},
+		},
+		fnFilter: []func(TOutput) bool{},
+		fnMap:    func(item TOutput) []TOutput { return []TOutput{item} },
+		pureMap:  true,
+		threads:  1,
+	}
+}
+
+// TakeWhile returns the leading elements of q for as long as pred holds. As
+// soon as pred returns false, the upstream query is cancelled.
+func (q *Queryable[TInput, TOutput]) TakeWhile(pred func(TOutput) bool) *Queryable[TOutput, TOutput] {
+	return &Queryable[TOutput, TOutput]{
+		parent: &takeQueryable[TOutput]{
+			parent:     q,
+			shouldTake: pred,
+		},
+		fnFilter: []func(TOutput) bool{},
+		fnMap:    func(item TOutput) []TOutput { return []TOutput{item} },
+		pureMap:  true,
+		threads:  1,
+	}
+}
+
+// Skip discards the first n elements of q and returns the rest. Unlike Take,
+// every upstream element still has to be produced, so there is nothing to
+// cancel early.
+func (q *Queryable[TInput, TOutput]) Skip(n int) *Queryable[TOutput, TOutput] {
+	var mu sync.Mutex
+	skipped := 0
+
+	return &Queryable[TOutput, TOutput]{
+		parent: q,
+		fnFilter: []func(TOutput) bool{
+			func(TOutput) bool {
+				mu.Lock()
+				defer mu.Unlock()
+
+				if skipped < n {
+					skipped++
+					return false
+				}
+				return true
+			},
+		},
+		fnMap:   func(item TOutput) []TOutput { return []TOutput{item} },
+		pureMap: true,
+		threads: 1,
+	}
+}
+
+// SkipWhile discards leading elements of q for as long as pred holds, then
+// returns every element from the first one that fails pred onward.
+func (q *Queryable[TInput, TOutput]) SkipWhile(pred func(TOutput) bool) *Queryable[TOutput, TOutput] {
+	var mu sync.Mutex
+	skipping := true
+
+	return &Queryable[TOutput, TOutput]{
+		parent: q,
+		fnFilter: []func(TOutput) bool{
+			func(item TOutput) bool {
+				mu.Lock()
+				defer mu.Unlock()
+
+				if skipping && pred(item) {
+					return false
+				}
+				skipping = false
+				return true
+			},
+		},
+		fnMap:   func(item TOutput) []TOutput { return []TOutput{item} },
+		pureMap: true,
+		threads: 1,
+	}
+}
+
+// zipQueryable pairs elements positionally from two queries, stopping as soon
+// as either side is exhausted.
+type zipQueryable[A, B, R any] struct {
+	qA        IQueryable[A]
+	qB        IQueryable[B]
+	fn        func(A, B) R
+	errorChan chan error
+}
+
+// Zip combines qA and qB positionally, applying fn to each pair. It stops as
+// soon as the shorter of the two queries is exhausted.
+func Zip[A, B, R any](qA IQueryable[A], qB IQueryable[B], fn func(A, B) R) *Queryable[R, R] {
+	z := &zipQueryable[A, B, R]{qA: qA, qB: qB, fn: fn}
+
+	return &Queryable[R, R]{
+		parent:   z,
+		fnFilter: []func(R) bool{},
+		fnMap:    func(item R) []R { return []R{item} },
+		pureMap:  true,
+		// zipQueryable.Run already preserves positional order on its own, so the
+		// wrapping Queryable must not fan this out across the default worker
+		// pool, which would reshuffle it.
+		threads: 1,
+	}
+}
+
+func (z *zipQueryable[A, B, R]) Wait(ctx context.Context) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case err, ok := <-z.errorChan:
+		if !ok {
+			return nil
+		}
+		return err
+	}
+}
+
+func (z *zipQueryable[A, B, R]) Run(ctx context.Context) <-chan R {
+	eg, ctx := errgroup.WithContext(ctx)
+	innerCtx, cancel := context.WithCancel(ctx)
+
+	outputChan := make(chan R)
+	z.errorChan = make(chan error, 1)
+
+	chanA := z.qA.Run(innerCtx)
+	chanB := z.qB.Run(innerCtx)
+
+	stoppedEarly := make(chan struct{})
+
+	eg.Go(func() error {
+		err := z.qA.Wait(innerCtx)
+		select {
+		case <-stoppedEarly:
+			if errors.Is(err, context.Canceled) {
+				return nil
+			}
+		default:
+		}
+		return err
+	})
+	eg.Go(func() error {
+		err := z.qB.Wait(innerCtx)
+		select {
+		case <-stoppedEarly:
+			if errors.Is(err, context.Canceled) {
+				return nil
+			}
+		default:
+		}
+		return err
+	})
+
+	eg.Go(func() (err error) {
+		defer close(outputChan)
+		defer func() {
+			if r := recover(); r != nil {
+				err = errors.Errorf("panic while zipping query %v: %v", z, r)
+			}
+		}()
+
+		for {
+			var (
+				a   A
+				b   B
+				okA bool
+				okB bool
+			)
+
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case a, okA = <-chanA:
+			}
+			if !okA {
+				close(stoppedEarly)
+				cancel()
+				return nil
+			}
+
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case b, okB = <-chanB:
+			}
+			if !okB {
+				close(stoppedEarly)
+				cancel()
+				return nil
+			}
+
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case outputChan <- z.fn(a, b):
+			}
+		}
+	})
+
+	go func() {
+		defer close(z.errorChan)
+		defer cancel()
+		if err := eg.Wait(); err != nil {
+			z.errorChan <- err
+		}
+	}()
+
+	return outputChan
+}
+
+// joinQueryable performs an inner hash join: the right-hand query is
+// materialised into a lookup table keyed by rightKey, then the left-hand
+// query is streamed and probed against it. Callers should pass the smaller
+// side as right to keep the build side cheap.
+type joinQueryable[L, R any, K comparable, Out any] struct {
+	left      IQueryable[L]
+	right     IQueryable[R]
+	leftKey   func(L) K
+	rightKey  func(R) K
+	projector func(L, R) Out
+	errorChan chan error
+}
+
+// Join performs an inner join between left and right, matching leftKey(l) ==
+// rightKey(r) and projecting each matching pair with projector. The right-hand
+// query is fully buffered to build the lookup table, so pass the smaller side
+// as right.
+func Join[L, R any, K comparable, Out any](left IQueryable[L], right IQueryable[R], leftKey func(L) K, rightKey func(R) K, projector func(L, R) Out) *Queryable[Out, Out] {
+	j := &joinQueryable[L, R, K, Out]{
+		left:      left,
+		right:     right,
+		leftKey:   leftKey,
+		rightKey:  rightKey,
+		projector: projector,
+	}
+
+	return &Queryable[Out, Out]{
+		parent:   j,
+		fnFilter: []func(Out) bool{},
+		fnMap:    func(item Out) []Out { return []Out{item} },
+		pureMap:  true,
+		// joinQueryable.Run emits matches in its own order; fanning the wrapper
+		// out across the default worker pool would reshuffle them.
+		threads: 1,
+	}
+}
+
+func (j *joinQueryable[L, R, K, Out]) Wait(ctx context.Context) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case err, ok := <-j.errorChan:
+		if !ok {
+			return nil
+		}
+		return err
+	}
+}
+
+func (j *joinQueryable[L, R, K, Out]) Run(ctx context.Context) <-chan Out {
+	eg, ctx := errgroup.WithContext(ctx)
+	outputChan := make(chan Out)
+	j.errorChan = make(chan error, 1)
+
+	rightChan := j.right.Run(ctx)
+	leftChan := j.left.Run(ctx)
+
+	eg.Go(func() error { return j.right.Wait(ctx) })
+	eg.Go(func() error { return j.left.Wait(ctx) })
+
+	eg.Go(func() (err error) {
+		defer close(outputChan)
+		defer func() {
+			if r := recover(); r != nil {
+				err = errors.Errorf("panic while joining query %v: %v", j, r)
+			}
+		}()
+
+		lookup := map[K][]R{}
+		for {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case item, ok := <-rightChan:
+				if !ok {
+					return j.probe(ctx, leftChan, lookup, outputChan)
+				}
+				key := j.rightKey(item)
+				lookup[key] = append(lookup[key], item)
+			}
+		}
+	})
+
+	go func() {
+		defer close(j.errorChan)
+		if err := eg.Wait(); err != nil {
+			j.errorChan <- err
+		}
+	}()
+
+	return outputChan
+}
+
+func (j *joinQueryable[L, R, K, Out]) probe(ctx context.Context, leftChan <-chan L, lookup map[K][]R, outputChan chan<- Out) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case item, ok := <-leftChan:
+			if !ok {
+				return nil
+			}
+			for _, match := range lookup[j.leftKey(item)] {
+				select {
+				case <-ctx.Done():
+					return ctx.Err()
+				case outputChan <- j.projector(item, match):
+				}
+			}
+		}
+	}
+}
+
+// unionQueryable streams left followed by right, emitting each distinct
+// element only once.
+type unionQueryable[T comparable] struct {
+	left      IQueryable[T]
+	right     IQueryable[T]
+	errorChan chan error
+}
+
+// Union returns the distinct elements of left and right, left's elements
+// first, each emitted only once.
+func Union[T comparable](left, right IQueryable[T]) *Queryable[T, T] {
+	u := &unionQueryable[T]{left: left, right: right}
+
+	return &Queryable[T, T]{
+		parent:   u,
+		fnFilter: []func(T) bool{},
+		fnMap:    func(item T) []T { return []T{item} },
+		pureMap:  true,
+		// unionQueryable.Run already streams left then right in order; fanning
+		// the wrapper out across the default worker pool would reshuffle it.
+		threads: 1,
+	}
+}
+
+func (u *unionQueryable[T]) Wait(ctx context.Context) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case err, ok := <-u.errorChan:
+		if !ok {
+			return nil
+		}
+		return err
+	}
+}
+
+func (u *unionQueryable[T]) Run(ctx context.Context) <-chan T {
+	eg, ctx := errgroup.WithContext(ctx)
+	outputChan := make(chan T)
+	u.errorChan = make(chan error, 1)
+
+	leftChan := u.left.Run(ctx)
+	rightChan := u.right.Run(ctx)
+
+	eg.Go(func() error { return u.left.Wait(ctx) })
+	eg.Go(func() error { return u.right.Wait(ctx) })
+
+	eg.Go(func() (err error) {
+		defer close(outputChan)
+		defer func() {
+			if r := recover(); r != nil {
+				err = errors.Errorf("panic while unioning query %v: %v", u, r)
+			}
+		}()
+
+		seen := map[T]struct{}{}
+		emit := func(item T) error {
+			if _, dup := seen[item]; dup {
+				return nil
+			}
+			seen[item] = struct{}{}
+
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case outputChan <- item:
+				return nil
+			}
+		}
+
+		lc, rc := leftChan, rightChan
+		for lc != nil || rc != nil {
+			// right is not read until left is exhausted, so left's elements
+			// come first.
+			activeRC := rc
+			if lc != nil {
+				activeRC = nil
+			}
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case item, ok := <-lc:
+				if !ok {
+					lc = nil
+					continue
+				}
+				if err := emit(item); err != nil {
+					return err
+				}
+			case item, ok := <-activeRC:
+				if !ok {
+					rc = nil
+					continue
+				}
+				if err := emit(item); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+
+	go func() {
+		defer close(u.errorChan)
+		if err := eg.Wait(); err != nil {
+			u.errorChan <- err
+		}
+	}()
+
+	return outputChan
+}
+
+// setOpQueryable materialises right into a set, then streams left, keeping
+// each distinct left element according to keep(present in right).
+type setOpQueryable[T comparable] struct {
+	left      IQueryable[T]
+	right     IQueryable[T]
+	keep      func(inRight bool) bool
+	errorChan chan error
+}
+
+// Intersect returns the distinct elements of left that are also present in
+// right. right is fully buffered to build the membership set, so pass the
+// smaller side as right.
+func Intersect[T comparable](left, right IQueryable[T]) *Queryable[T, T] {
+	return newSetOp(left, right, func(inRight bool) bool { return inRight })
+}
+
+// Except returns the distinct elements of left that are not present in right.
+// right is fully buffered to build the membership set, so pass the smaller
+// side as right.
+func Except[T comparable](left, right IQueryable[T]) *Queryable[T, T] {
+	return newSetOp(left, right, func(inRight bool) bool { return !inRight })
+}
+
+func newSetOp[T comparable](left, right IQueryable[T], keep func(inRight bool) bool) *Queryable[T, T] {
+	op := &setOpQueryable[T]{left: left, right: right, keep: keep}
+
+	return &Queryable[T, T]{
+		parent:   op,
+		fnFilter: []func(T) bool{},
+		fnMap:    func(item T) []T { return []T{item} },
+		pureMap:  true,
+		// setOpQueryable.Run already streams left in order; fanning the wrapper
+		// out across the default worker pool would reshuffle it.
+		threads: 1,
+	}
+}
+
+func (op *setOpQueryable[T]) Wait(ctx context.Context) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case err, ok := <-op.errorChan:
+		if !ok {
+			return nil
+		}
+		return err
+	}
+}
+
+func (op *setOpQueryable[T]) Run(ctx context.Context) <-chan T {
+	eg, ctx := errgroup.WithContext(ctx)
+	outputChan := make(chan T)
+	op.errorChan = make(chan error, 1)
+
+	rightChan := op.right.Run(ctx)
+	leftChan := op.left.Run(ctx)
+
+	eg.Go(func() error { return op.right.Wait(ctx) })
+	eg.Go(func() error { return op.left.Wait(ctx) })
+
+	eg.Go(func() (err error) {
+		defer close(outputChan)
+		defer func() {
+			if r := recover(); r != nil {
+				err = errors.Errorf("panic while combining query %v: %v", op, r)
+			}
+		}()
+
+		rightSet := map[T]struct{}{}
+		for {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case item, ok := <-rightChan:
+				if !ok {
+					return op.stream(ctx, leftChan, rightSet, outputChan)
+				}
+				rightSet[item] = struct{}{}
+			}
+		}
+	})
+
+	go func() {
+		defer close(op.errorChan)
+		if err := eg.Wait(); err != nil {
+			op.errorChan <- err
+		}
+	}()
+
+	return outputChan
+}
+
+func (op *setOpQueryable[T]) stream(ctx context.Context, leftChan <-chan T, rightSet map[T]struct{}, outputChan chan<- T) error {
+	seen := map[T]struct{}{}
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case item, ok := <-leftChan:
+			if !ok {
+				return nil
+			}
+			if _, dup := seen[item]; dup {
+				continue
+			}
+			seen[item] = struct{}{}
+
+			_, inRight := rightSet[item]
+			if !op.keep(inRight) {
+				continue
+			}
+
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case outputChan <- item:
+			}
+		}
+	}
+}
@@ -0,0 +1,154 @@
+package goql
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQueryable_Distinct(t *testing.T) {
+	ctx := context.Background()
+	inputData := []int{1, 2, 2, 3, 1, 4}
+	expectedData := []int{1, 2, 3, 4}
+
+	output, err := Distinct[int](FromArray(inputData).WithThreads(1)).ToArray(ctx)
+
+	assert.Nil(t, err)
+	assert.Equal(t, expectedData, output)
+}
+
+func TestQueryable_DistinctBy(t *testing.T) {
+	ctx := context.Background()
+	inputData := []int{1, 2, 11, 3, 12}
+	expectedData := []int{1, 2, 3}
+
+	output, err := DistinctBy(func(i int) int { return i % 10 }, FromArray(inputData).WithThreads(1)).ToArray(ctx)
+
+	assert.Nil(t, err)
+	assert.Equal(t, expectedData, output)
+}
+
+func TestQueryable_Take(t *testing.T) {
+	ctx := context.Background()
+	inputData := []int{1, 2, 3, 4, 5}
+	expectedData := []int{1, 2, 3}
+
+	output, err := FromArray(inputData).WithThreads(1).Take(3).ToArray(ctx)
+
+	assert.Nil(t, err)
+	assert.Equal(t, expectedData, output)
+}
+
+func TestQueryable_TakeWhile(t *testing.T) {
+	ctx := context.Background()
+	inputData := []int{1, 2, 3, 4, 1}
+	expectedData := []int{1, 2, 3}
+
+	output, err := FromArray(inputData).WithThreads(1).TakeWhile(func(i int) bool { return i < 4 }).ToArray(ctx)
+
+	assert.Nil(t, err)
+	assert.Equal(t, expectedData, output)
+}
+
+func TestQueryable_Skip(t *testing.T) {
+	ctx := context.Background()
+	inputData := []int{1, 2, 3, 4, 5}
+	expectedData := []int{3, 4, 5}
+
+	output, err := FromArray(inputData).WithThreads(1).Skip(2).ToArray(ctx)
+
+	assert.Nil(t, err)
+	assert.Equal(t, expectedData, output)
+}
+
+func TestQueryable_SkipWhile(t *testing.T) {
+	ctx := context.Background()
+	inputData := []int{1, 2, 3, 4, 1}
+	expectedData := []int{4, 1}
+
+	output, err := FromArray(inputData).WithThreads(1).SkipWhile(func(i int) bool { return i < 4 }).ToArray(ctx)
+
+	assert.Nil(t, err)
+	assert.Equal(t, expectedData, output)
+}
+
+func TestQueryable_Zip(t *testing.T) {
+	ctx := context.Background()
+	left := []int{1, 2, 3}
+	right := []string{"a", "b", "c", "d"}
+	expectedData := []string{"1a", "2b", "3c"}
+
+	output, err := Zip(
+		FromArray(left).WithThreads(1),
+		FromArray(right).WithThreads(1),
+		func(i int, s string) string { return strconv.Itoa(i) + s },
+	).ToArray(ctx)
+
+	assert.Nil(t, err)
+	assert.Equal(t, expectedData, output)
+}
+
+func TestQueryable_Join(t *testing.T) {
+	ctx := context.Background()
+	left := []int{1, 2, 3}
+	right := []string{"a1", "b2", "c2"}
+	expectedData := []string{"1a1", "2b2", "2c2"}
+
+	output, err := Join(
+		FromArray(left).WithThreads(1),
+		FromArray(right).WithThreads(1),
+		func(i int) string { return strconv.Itoa(i) },
+		func(s string) string { return s[1:] },
+		func(i int, s string) string { return strconv.Itoa(i) + s },
+	).ToArray(ctx, OrderAsc(func(s string) string { return s }))
+
+	assert.Nil(t, err)
+	assert.Equal(t, expectedData, output)
+}
+
+func TestQueryable_Union(t *testing.T) {
+	ctx := context.Background()
+	left := []int{1, 2, 3}
+	right := []int{3, 4, 5}
+	expectedData := []int{1, 2, 3, 4, 5}
+
+	output, err := Union(
+		FromArray(left).WithThreads(1),
+		FromArray(right).WithThreads(1),
+	).ToArray(ctx, OrderAsc(func(i int) int { return i }))
+
+	assert.Nil(t, err)
+	assert.Equal(t, expectedData, output)
+}
+
+func TestQueryable_Intersect(t *testing.T) {
+	ctx := context.Background()
+	left := []int{1, 2, 3}
+	right := []int{2, 3, 4}
+	expectedData := []int{2, 3}
+
+	output, err := Intersect(
+		FromArray(left).WithThreads(1),
+		FromArray(right).WithThreads(1),
+	).ToArray(ctx, OrderAsc(func(i int) int { return i }))
+
+	assert.Nil(t, err)
+	assert.Equal(t, expectedData, output)
+}
+
+func TestQueryable_Except(t *testing.T) {
+	ctx := context.Background()
+	left := []int{1, 2, 3}
+	right := []int{2, 3, 4}
+	expectedData := []int{1}
+
+	output, err := Except(
+		FromArray(left).WithThreads(1),
+		FromArray(right).WithThreads(1),
+	).ToArray(ctx, OrderAsc(func(i int) int { return i }))
+
+	assert.Nil(t, err)
+	assert.Equal(t, expectedData, output)
+}
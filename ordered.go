@@ -0,0 +1,170 @@
+package goql
+
+import (
+	"container/heap"
+	"context"
+	"sync"
+
+	"github.com/pkg/errors"
+	"golang.org/x/sync/errgroup"
+)
+
+// orderedItem tags an input element with the sequence number it arrived in,
+// so the merger in runOrdered can put worker output back in that order.
+type orderedItem[T any] struct {
+	seq  int
+	item T
+}
+
+// orderedResult carries a worker's filter/map output for one orderedItem.
+// items is nil when the input was filtered out.
+type orderedResult[T any] struct {
+	seq   int
+	items []T
+}
+
+// orderedResultHeap is a min-heap of orderedResult ordered by seq, used by the
+// merger goroutine to release contiguous results as soon as they're ready.
+type orderedResultHeap[T any] []orderedResult[T]
+
+func (h orderedResultHeap[T]) Len() int          { return len(h) }
+func (h orderedResultHeap[T]) Less(i, j int) bool { return h[i].seq < h[j].seq }
+func (h orderedResultHeap[T]) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+
+func (h *orderedResultHeap[T]) Push(x any) {
+	*h = append(*h, x.(orderedResult[T]))
+}
+
+func (h *orderedResultHeap[T]) Pop() any {
+	old := *h
+	n := len(old)
+	item := old[n-1]
+	*h = old[:n-1]
+	return item
+}
+
+// orderedProcessItem filters and maps a single tagged item the same way
+// processItem does for the unordered path, reporting a filtered-out or
+// policy-dropped item as a nil items slice rather than an error. It returns a
+// non-nil error only under ErrorAbort, to fail the whole query.
+func (q *Queryable[TInput, TOutput]) orderedProcessItem(ctx context.Context, thread int, data TInput) (items []TOutput, abortErr error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err := errors.Errorf("Thread %v: panic while filtering/mapping query %v: %v", thread, q, r)
+			items, abortErr = nil, q.handleItemError(err)
+		}
+	}()
+
+	ok, err := q.passesFilters(ctx, data)
+	if err != nil {
+		return nil, q.handleItemError(err)
+	}
+	if !ok {
+		return nil, nil
+	}
+
+	items, err = q.mapItem(ctx, data)
+	if err != nil {
+		return nil, q.handleItemError(err)
+	}
+
+	if !q.passesPostMapFilters(items) {
+		return nil, nil
+	}
+
+	return items, nil
+}
+
+// runOrdered fans out to q.threads workers like the default unordered path,
+// but tags each input with a sequence number as it's read from q.inputChan and
+// merges worker output back into that order with a min-heap before writing to
+// outputChan. A SelectMany's multiple outputs for one input keep their
+// relative order since a worker always emits them together as one result.
+func (q *Queryable[TInput, TOutput]) runOrdered(ctx context.Context, eg *errgroup.Group, outputChan chan<- TOutput) {
+	taggedChan := make(chan orderedItem[TInput])
+
+	eg.Go(func() error {
+		defer close(taggedChan)
+		seq := 0
+		for {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case data, ok := <-q.inputChan:
+				if !ok {
+					return nil
+				}
+				select {
+				case <-ctx.Done():
+					return ctx.Err()
+				case taggedChan <- orderedItem[TInput]{seq: seq, item: data}:
+				}
+				seq++
+			}
+		}
+	})
+
+	resultChan := make(chan orderedResult[TOutput])
+	var workers sync.WaitGroup
+
+	for i := 0; i < q.threads; i++ {
+		workers.Add(1)
+		eg.Go(func() error {
+			defer workers.Done()
+			for {
+				select {
+				case <-ctx.Done():
+					return ctx.Err()
+				case tagged, ok := <-taggedChan:
+					if !ok {
+						return nil
+					}
+
+					items, abortErr := q.orderedProcessItem(ctx, i, tagged.item)
+					if abortErr != nil {
+						return abortErr
+					}
+
+					select {
+					case <-ctx.Done():
+						return ctx.Err()
+					case resultChan <- orderedResult[TOutput]{seq: tagged.seq, items: items}:
+					}
+				}
+			}
+		})
+	}
+
+	eg.Go(func() error {
+		workers.Wait()
+		close(resultChan)
+		return nil
+	})
+
+	eg.Go(func() error {
+		pending := &orderedResultHeap[TOutput]{}
+		next := 0
+		for {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case res, ok := <-resultChan:
+				if !ok {
+					return nil
+				}
+				heap.Push(pending, res)
+				for pending.Len() > 0 && (*pending)[0].seq == next {
+					r := heap.Pop(pending).(orderedResult[TOutput])
+					for _, item := range r.items {
+						select {
+						case <-ctx.Done():
+							return ctx.Err()
+						case outputChan <- item:
+						}
+					}
+					next++
+				}
+			}
+		}
+	})
+}
@@ -0,0 +1,40 @@
+package goql
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQueryable_WithOrderedParallelism(t *testing.T) {
+	ctx := context.Background()
+	inputData := []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+
+	output, err :=
+		Select(func(i int) int {
+			// Vary per-item work so a naive fan-out would race items out of order.
+			time.Sleep(time.Duration(10-i) * time.Millisecond)
+			return i
+		}, FromArray(inputData).WithThreads(1)).
+			WithOrderedParallelism(4).
+			ToArray(ctx)
+
+	assert.Nil(t, err)
+	assert.Equal(t, inputData, output)
+}
+
+func TestQueryable_WithOrderedParallelism_SelectMany(t *testing.T) {
+	ctx := context.Background()
+	inputData := []int{1, 2, 3}
+	expectedData := []int{1, -1, 2, -2, 3, -3}
+
+	output, err :=
+		SelectMany(func(i int) []int { return []int{i, -i} }, FromArray(inputData).WithThreads(1)).
+			WithOrderedParallelism(4).
+			ToArray(ctx)
+
+	assert.Nil(t, err)
+	assert.Equal(t, expectedData, output)
+}
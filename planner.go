@@ -0,0 +1,97 @@
+package goql
+
+import "sync/atomic"
+
+// planFilterPushCount tracks how many Where predicates the planner below has
+// fused into an upstream stage. It exists purely so tests can assert that
+// fusion actually happened, without reaching into pipeline internals.
+var planFilterPushCount int64
+
+func planResetStats() {
+	atomic.StoreInt64(&planFilterPushCount, 0)
+}
+
+func planPushedFilterCount() int {
+	return int(atomic.LoadInt64(&planFilterPushCount))
+}
+
+// filterPushable is implemented by any Queryable whose map function is a pure,
+// single-result transform: pushFilter lets a downstream stage hand it a
+// predicate to evaluate on its own output, instead of on its output after a
+// dedicated downstream stage remaps it.
+type filterPushable[T any] interface {
+	IQueryable[T]
+	pushFilter(fn func(T) bool) (filterPushable[T], bool)
+}
+
+// pushFilter accepts a predicate over q's output and, if q's map function is
+// pure, returns a copy of q with the predicate added to postMapFilters,
+// evaluated against q's own mapped output rather than recomputed. It returns a
+// copy rather than mutating q in place because q may be shared: the same
+// Queryable can be the parent of several independent chains, and fusing a
+// predicate into one chain must not leak into another's results. Fusing is
+// itself safe because Where predicates are assumed pure: moving one earlier
+// in the pipeline cannot change which items ultimately pass, only how early
+// they get rejected.
+func (q *Queryable[TInput, TOutput]) pushFilter(fn func(TOutput) bool) (filterPushable[TOutput], bool) {
+	if !q.pureMap {
+		return nil, false
+	}
+
+	clone := &Queryable[TInput, TOutput]{
+		parent:         q.parent,
+		inputData:      q.inputData,
+		inputChan:      q.inputChan,
+		inputIter:      q.inputIter,
+		fnFilter:       q.fnFilter,
+		pushableFilter: q.pushableFilter,
+		fnFilterE:      q.fnFilterE,
+		fnMap:          q.fnMap,
+		fnMapE:         q.fnMapE,
+		postMapFilters: append(append([]func(TOutput) bool{}, q.postMapFilters...), fn),
+		pureMap:        q.pureMap,
+		threads:        q.threads,
+		ordered:        q.ordered,
+		errorPolicy:    q.errorPolicy,
+		retryAttempts:  q.retryAttempts,
+		retryBackoff:   q.retryBackoff,
+	}
+
+	return clone, true
+}
+
+// planPushFilters is run at the start of Run for every query with a parent.
+// It walks q's pushable predicates and, for as many as the parent accepts,
+// relocates them onto a private copy of the parent so they run against the
+// parent's own mapped output instead of via a dedicated downstream stage, then
+// points q at that copy instead of the shared original. This collapses chains
+// like Select(...).Where(...) into a single worker pool that maps each item
+// exactly once and discards rejected items in the parent's workers, before
+// they cross the channel to q. The parent copy's own Run call in turn pushes
+// its own Where predicates further up the chain; the predicates it received
+// here stay in its postMapFilters and are not moved again.
+func planPushFilters[TInput, TOutput any](q *Queryable[TInput, TOutput]) {
+	pushable, ok := q.parent.(filterPushable[TInput])
+	if !ok {
+		return
+	}
+
+	current := pushable
+	remaining := q.pushableFilter[:0]
+	pushed := 0
+	for _, fn := range q.pushableFilter {
+		next, ok := current.pushFilter(fn)
+		if !ok {
+			remaining = append(remaining, fn)
+			continue
+		}
+		current = next
+		pushed++
+	}
+	q.pushableFilter = remaining
+
+	if pushed > 0 {
+		q.parent = current
+		atomic.AddInt64(&planFilterPushCount, int64(pushed))
+	}
+}
@@ -0,0 +1,86 @@
+package goql
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQueryable_PlanPushesFilterPastSelect(t *testing.T) {
+	ctx := context.Background()
+	inputData := []int{1, 2, 3, 4, 5}
+	expectedData := []string{"i2", "i4"}
+
+	planResetStats()
+
+	output, err :=
+		Select(
+			func(s string) string { return s },
+			Select(
+				func(i int) string { return "i" + strconv.Itoa(i) },
+				FromArray(inputData).WithThreads(1),
+			).WithThreads(1)).
+			WithThreads(1).
+			Where(func(s string) bool { return s[1]%2 == 0 }).
+			ToArray(ctx)
+
+	assert.Nil(t, err)
+	assert.Equal(t, expectedData, output)
+	// The predicate is pushed past both pure Select stages: once from the
+	// outer Select onto the inner one, and again from the inner one onto the
+	// FromArray source.
+	assert.Equal(t, 2, planPushedFilterCount())
+}
+
+func TestQueryable_PlanDoesNotCorruptSharedParent(t *testing.T) {
+	ctx := context.Background()
+	inputData := []int{1, 2, 3, 4, 5, 6}
+
+	base := FromArray(inputData).WithThreads(1)
+	left := Select(func(i int) string { return "i" + strconv.Itoa(i) }, base).
+		WithThreads(1).
+		Where(func(i int) bool { return i%2 == 0 })
+	right := Select(func(i int) string { return "i" + strconv.Itoa(i) }, base).
+		WithThreads(1).
+		Where(func(i int) bool { return i%2 != 0 })
+
+	leftOutput, err := left.ToArray(ctx)
+	assert.Nil(t, err)
+
+	rightOutput, err := right.ToArray(ctx)
+	assert.Nil(t, err)
+
+	assert.Equal(t, []string{"i2", "i4", "i6"}, leftOutput)
+	assert.Equal(t, []string{"i1", "i3", "i5"}, rightOutput)
+}
+
+func TestQueryable_PlanMatchesUnfusedPipeline(t *testing.T) {
+	ctx := context.Background()
+	inputData := []int{1, 2, 3, 4, 5, 6, 7, 8}
+
+	fused, err := Select(
+		func(s string) string { return s },
+		Select(
+			func(i int) string { return "i" + strconv.Itoa(i) },
+			FromArray(inputData).WithThreads(1),
+		).WithThreads(1)).
+		WithThreads(1).
+		Where(func(s string) bool { return len(s)%2 == 0 }).
+		ToArray(ctx)
+	assert.Nil(t, err)
+
+	unfused, err := FromArray(inputData).
+		WithThreads(1).
+		Where(func(i int) bool { return len("i"+strconv.Itoa(i))%2 == 0 }).
+		ToArray(ctx)
+	assert.Nil(t, err)
+
+	var expected []string
+	for _, i := range unfused {
+		expected = append(expected, "i"+strconv.Itoa(i))
+	}
+
+	assert.Equal(t, expected, fused)
+}
@@ -4,6 +4,8 @@ import (
 	"cmp"
 	"context"
 	"runtime"
+	"sync"
+	"time"
 
 	"github.com/pkg/errors"
 	"golang.org/x/sync/errgroup"
@@ -15,13 +17,26 @@ type IQueryable[TOutput any] interface {
 }
 
 type Queryable[TInput, TOutput any] struct {
-	parent    IQueryable[TInput]
-	inputData []TInput
-	inputChan <-chan TInput
-	errorChan chan error
-	fnFilter  []func(TInput) bool
-	fnMap     func(TInput) []TOutput
-	threads   int
+	parent         IQueryable[TInput]
+	inputData      []TInput
+	inputChan      <-chan TInput
+	inputIter      Iterator[TInput]
+	errorChan      chan error
+	fnFilter       []func(TInput) bool
+	pushableFilter []func(TInput) bool
+	fnFilterE      []func(TInput) (bool, error)
+	fnMap          func(TInput) []TOutput
+	fnMapE         func(TInput) ([]TOutput, error)
+	postMapFilters []func(TOutput) bool
+	pureMap        bool
+	threads        int
+	ordered        bool
+
+	errorPolicy   OnError
+	retryAttempts int
+	retryBackoff  func(int) time.Duration
+	collectMu     sync.Mutex
+	collectedErrs []error
 }
 
 func FromArray[TDataType any](inputData []TDataType) *Queryable[TDataType, TDataType] {
@@ -30,6 +45,7 @@ func FromArray[TDataType any](inputData []TDataType) *Queryable[TDataType, TData
 		inputChan: nil,
 		fnFilter:  []func(TDataType) bool{},
 		fnMap:     func(data TDataType) []TDataType { return []TDataType{data} },
+		pureMap:   true,
 		threads:   max(min(1, runtime.NumCPU()), 128),
 	}
 }
@@ -40,12 +56,26 @@ func FromChan[TDataType any](inputData <-chan TDataType) *Queryable[TDataType, T
 		inputChan: inputData,
 		fnFilter:  []func(TDataType) bool{},
 		fnMap:     func(data TDataType) []TDataType { return []TDataType{data} },
+		pureMap:   true,
 		threads:   max(min(1, runtime.NumCPU()), 128),
 	}
 }
 
+// Where adds a filter predicate. Predicates are assumed to be pure and
+// side-effect free, the same way Select's mapping function is, so the planner
+// invoked by Run is free to evaluate them earlier in the pipeline than they
+// were declared.
 func (q *Queryable[TInput, TOutput]) Where(fn func(TInput) bool) *Queryable[TInput, TOutput] {
-	q.fnFilter = append(q.fnFilter, fn)
+	q.pushableFilter = append(q.pushableFilter, fn)
+	return q
+}
+
+// WhereE is the error-returning counterpart to Where, for predicates that can
+// fail. A failure is retried per WithRetry and, once retries are exhausted,
+// handled per WithErrorPolicy. Unlike Where's predicates, WhereE predicates
+// are never pushed upstream by the planner, since they're not assumed pure.
+func (q *Queryable[TInput, TOutput]) WhereE(fn func(TInput) (bool, error)) *Queryable[TInput, TOutput] {
+	q.fnFilterE = append(q.fnFilterE, fn)
 	return q
 }
 
@@ -58,6 +88,16 @@ func (q *Queryable[TInput, TOutput]) WithThreads(threads int) *Queryable[TInput,
 	return q
 }
 
+// WithOrderedParallelism fans out to n workers like WithThreads, but tags each
+// input with its arrival sequence number and merges worker output back into
+// that order before it reaches outputChan. Use it when parallel CPU is needed
+// but the order of the source must still be preserved.
+func (q *Queryable[TInput, TOutput]) WithOrderedParallelism(n int) *Queryable[TInput, TOutput] {
+	q.WithThreads(n)
+	q.ordered = true
+	return q
+}
+
 func (q *Queryable[TInput, TOutput]) Wait(ctx context.Context) error {
 	select {
 	case <-ctx.Done():
@@ -70,6 +110,134 @@ func (q *Queryable[TInput, TOutput]) Wait(ctx context.Context) error {
 	}
 }
 
+// passesFilters reports whether data survives both q's own predicates and any
+// predicates a downstream stage has pushed onto q, then evaluates any WhereE
+// predicates, retrying transient failures per q.WithRetry. A non-nil error
+// means a WhereE predicate failed after exhausting retries.
+func (q *Queryable[TInput, TOutput]) passesFilters(ctx context.Context, data TInput) (bool, error) {
+	for _, fnFilter := range q.fnFilter {
+		if !fnFilter(data) {
+			return false, nil
+		}
+	}
+	for _, fnFilter := range q.pushableFilter {
+		if !fnFilter(data) {
+			return false, nil
+		}
+	}
+	for _, fnFilterE := range q.fnFilterE {
+		var ok bool
+		err := q.withRetry(ctx, func() (err error) {
+			ok, err = fnFilterE(data)
+			return err
+		})
+		if err != nil {
+			return false, err
+		}
+		if !ok {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// mapItem runs q's mapping function over data, preferring the error-returning
+// fnMapE set by SelectE/SelectManyE, and retrying transient failures per
+// q.WithRetry.
+func (q *Queryable[TInput, TOutput]) mapItem(ctx context.Context, data TInput) ([]TOutput, error) {
+	if q.fnMapE == nil {
+		return q.fnMap(data), nil
+	}
+
+	var items []TOutput
+	err := q.withRetry(ctx, func() (err error) {
+		items, err = q.fnMapE(data)
+		return err
+	})
+	return items, err
+}
+
+// passesPostMapFilters reports whether items survives every predicate the
+// planner has fused onto q from a downstream Where. These run against the
+// already-computed mapped value instead of recomputing it, so fusing a
+// predicate onto a pure mapper costs nothing extra per item. Fusion only ever
+// targets pureMap stages, which always produce exactly one item, so an empty
+// items only happens if mapping itself produced nothing.
+func (q *Queryable[TInput, TOutput]) passesPostMapFilters(items []TOutput) bool {
+	if len(q.postMapFilters) == 0 {
+		return true
+	}
+	if len(items) == 0 {
+		return false
+	}
+	for _, fn := range q.postMapFilters {
+		if !fn(items[0]) {
+			return false
+		}
+	}
+	return true
+}
+
+// withRetry calls fn, retrying up to q.retryAttempts times with q.retryBackoff
+// between attempts when fn returns an error. With no WithRetry configured, fn
+// runs once.
+func (q *Queryable[TInput, TOutput]) withRetry(ctx context.Context, fn func() error) error {
+	var err error
+	for attempt := 0; ; attempt++ {
+		err = fn()
+		if err == nil || attempt >= q.retryAttempts {
+			return err
+		}
+		if q.retryBackoff == nil {
+			continue
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(q.retryBackoff(attempt + 1)):
+		}
+	}
+}
+
+// processItem filters and maps a single item, sending its output to
+// outputChan. A panic while doing so, or an error from a WhereE/SelectE/
+// SelectManyE call, is handled per q.errorPolicy: it returns a non-nil error
+// only under ErrorAbort, to fail the whole query.
+func (q *Queryable[TInput, TOutput]) processItem(ctx context.Context, thread int, data TInput, outputChan chan<- TOutput) (abortErr error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err := errors.Errorf("Thread %v: panic while filtering/mapping query %v: %v", thread, q, r)
+			abortErr = q.handleItemError(err)
+		}
+	}()
+
+	ok, err := q.passesFilters(ctx, data)
+	if err != nil {
+		return q.handleItemError(err)
+	}
+	if !ok {
+		return nil
+	}
+
+	items, err := q.mapItem(ctx, data)
+	if err != nil {
+		return q.handleItemError(err)
+	}
+
+	if !q.passesPostMapFilters(items) {
+		return nil
+	}
+
+	for _, item := range items {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case outputChan <- item:
+		}
+	}
+	return nil
+}
+
 func (q *Queryable[Input, TOutput]) Run(ctx context.Context) <-chan TOutput {
 	eg, ctx := errgroup.WithContext(ctx)
 
@@ -93,50 +261,62 @@ func (q *Queryable[Input, TOutput]) Run(ctx context.Context) <-chan TOutput {
 		})
 	}
 
-	// Check parent query
-	if q.parent != nil {
+	// Convert an iterator source to an input channel
+	if q.inputIter != nil {
+		inputChan := make(chan Input)
+		q.inputChan = inputChan
 		eg.Go(func() error {
-			err := q.parent.Wait(ctx)
-			return err
+			defer close(inputChan)
+			for {
+				item, ok := q.inputIter()
+				if !ok {
+					return nil
+				}
+				select {
+				case <-ctx.Done():
+					return ctx.Err()
+				case inputChan <- item:
+				}
+			}
 		})
+	}
 
+	// Check parent query
+	if q.parent != nil {
+		planPushFilters(q)
+
+		// Run must happen before Wait is handed to the errgroup: Run is what
+		// initializes the parent's errorChan, and Wait reads it. Starting the
+		// Wait goroutine first races that initialization against whichever
+		// goroutine runs it.
 		q.inputChan = q.parent.Run(ctx)
+
+		eg.Go(func() error {
+			return q.parent.Wait(ctx)
+		})
 	}
 
 	// Do the filter and map
-	for i := 0; i < q.threads; i++ {
-		eg.Go(func() (err error) {
-			defer func() {
-				if r := recover(); r != nil {
-					err = errors.Errorf("Thread %v: panic while filtering/mapping query %v: %v", i, q, r)
-				}
-			}()
-			for {
-				select {
-				case <-ctx.Done():
-					return ctx.Err()
-				case data, ok := <-q.inputChan:
-					if !ok {
-						return nil
-					}
-					shouldMap := true
-					for _, fnFilter := range q.fnFilter {
-						if !fnFilter(data) {
-							shouldMap = false
-							break
+	if q.ordered {
+		q.runOrdered(ctx, eg, outputChan)
+	} else {
+		for i := 0; i < q.threads; i++ {
+			eg.Go(func() error {
+				for {
+					select {
+					case <-ctx.Done():
+						return ctx.Err()
+					case data, ok := <-q.inputChan:
+						if !ok {
+							return nil
+						}
+						if abortErr := q.processItem(ctx, i, data, outputChan); abortErr != nil {
+							return abortErr
 						}
-					}
-
-					if !shouldMap {
-						continue
-					}
-
-					for _, item := range q.fnMap(data) {
-						outputChan <- item
 					}
 				}
-			}
-		})
+			})
+		}
 	}
 
 	// Monitor error group and close the channels
@@ -144,6 +324,12 @@ func (q *Queryable[Input, TOutput]) Run(ctx context.Context) <-chan TOutput {
 		defer close(outputChan)
 		defer close(q.errorChan)
 		err := eg.Wait()
+		if collected := q.collectedError(); collected != nil {
+			if err != nil {
+				collected.Errors = append(collected.Errors, err)
+			}
+			err = collected
+		}
 		if err != nil {
 			q.errorChan <- err
 		}
@@ -178,6 +364,7 @@ func Select[TInput, TOutput any](fnMap func(TInput) TOutput, q IQueryable[TInput
 		inputChan: nil,
 		fnFilter:  []func(TInput) bool{},
 		fnMap:     func(item TInput) []TOutput { return []TOutput{fnMap(item)} },
+		pureMap:   true,
 		threads:   max(min(1, runtime.NumCPU()), 128),
 	}
 }
@@ -193,6 +380,40 @@ func SelectMany[TInput, TOutput any](fnMap func(TInput) []TOutput, q IQueryable[
 	}
 }
 
+// SelectE is the error-returning counterpart to Select, for mappers that can
+// fail. A failure is retried per WithRetry and, once retries are exhausted,
+// handled per WithErrorPolicy.
+func SelectE[TInput, TOutput any](fnMap func(TInput) (TOutput, error), q IQueryable[TInput]) *Queryable[TInput, TOutput] {
+	return &Queryable[TInput, TOutput]{
+		parent:    q,
+		inputData: nil,
+		inputChan: nil,
+		fnFilter:  []func(TInput) bool{},
+		fnMapE: func(item TInput) ([]TOutput, error) {
+			out, err := fnMap(item)
+			if err != nil {
+				return nil, err
+			}
+			return []TOutput{out}, nil
+		},
+		threads: max(min(1, runtime.NumCPU()), 128),
+	}
+}
+
+// SelectManyE is the error-returning counterpart to SelectMany, for mappers
+// that can fail. A failure is retried per WithRetry and, once retries are
+// exhausted, handled per WithErrorPolicy.
+func SelectManyE[TInput, TOutput any](fnMap func(TInput) ([]TOutput, error), q IQueryable[TInput]) *Queryable[TInput, TOutput] {
+	return &Queryable[TInput, TOutput]{
+		parent:    q,
+		inputData: nil,
+		inputChan: nil,
+		fnFilter:  []func(TInput) bool{},
+		fnMapE:    fnMap,
+		threads:   max(min(1, runtime.NumCPU()), 128),
+	}
+}
+
 func Reduce[TInput, TOutput any](ctx context.Context, start TOutput, fnReduce func(TInput, TOutput) TOutput, q IQueryable[TInput]) (TOutput, error) {
 	outputChan := q.Run(ctx)
 	output := start
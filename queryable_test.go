@@ -143,3 +143,40 @@ func TestQueryable_FilterError(t *testing.T) {
 
 	assert.NotNil(t, err)
 }
+
+func TestQueryable_FilterError_ErrorSkip(t *testing.T) {
+	ctx := context.Background()
+	inputData := []int{0, 1, 2, 3, 4, 5}
+	expectedData := []string{"i4", "i2"}
+	output, err :=
+		Select(
+			func(i int) string { return "i" + strconv.Itoa(i) },
+			FromArray(inputData).
+				WithThreads(1).
+				WithErrorPolicy(ErrorSkip).
+				Where(func(i int) bool {
+					_ = 1 / i
+					return i%2 == 0
+				})).
+			ToArray(ctx,
+				OrderDesc(func(i string) string { return i }))
+
+	assert.Nil(t, err)
+	assert.Equal(t, expectedData, output)
+}
+
+func TestQueryable_FilterError_ErrorCollect(t *testing.T) {
+	ctx := context.Background()
+	inputData := []int{0, 1, 2}
+	source :=
+		FromArray(inputData).
+			WithThreads(1).
+			WithErrorPolicy(ErrorCollect).
+			Where(func(i int) bool { return 1/i == 1 })
+
+	_, err := source.ToArray(ctx)
+
+	multiErr, ok := err.(*MultiError)
+	assert.True(t, ok)
+	assert.Equal(t, 1, len(multiErr.Errors))
+}
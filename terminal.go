@@ -0,0 +1,191 @@
+package goql
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+)
+
+// ToSet drains q into a set of its distinct elements.
+func ToSet[T comparable](ctx context.Context, q IQueryable[T]) (map[T]struct{}, error) {
+	outputChan := q.Run(ctx)
+	output := map[T]struct{}{}
+
+	for item := range outputChan {
+		output[item] = struct{}{}
+	}
+
+	if err := q.Wait(ctx); err != nil {
+		return nil, err
+	}
+
+	return output, nil
+}
+
+// ToChannel runs q and hands back its output channel directly, alongside a
+// channel that receives at most one error once the pipeline finishes. Use it
+// to consume a query as a stream without buffering it into a slice first.
+func ToChannel[T any](ctx context.Context, q IQueryable[T]) (<-chan T, <-chan error) {
+	outputChan := q.Run(ctx)
+	errChan := make(chan error, 1)
+
+	go func() {
+		defer close(errChan)
+		if err := q.Wait(ctx); err != nil {
+			errChan <- err
+		}
+	}()
+
+	return outputChan, errChan
+}
+
+type mapOptions[V any] struct {
+	merge func(existing, next V) V
+}
+
+// MapOption configures ToMap. It's parameterized only on V, not K, since a
+// merge function never needs the key type: K would otherwise appear nowhere
+// in MapOption's signature and couldn't be inferred at the call site.
+type MapOption[V any] func(*mapOptions[V])
+
+// WithMerge resolves a duplicate key in ToMap by combining the existing and
+// incoming values instead of ToMap returning an error.
+func WithMerge[V any](fn func(existing, next V) V) MapOption[V] {
+	return func(o *mapOptions[V]) { o.merge = fn }
+}
+
+// ToMap drains q into a map keyed by keyFn with values produced by valFn. A
+// duplicate key is an error unless WithMerge is supplied.
+func ToMap[TInput any, K comparable, V any](ctx context.Context, keyFn func(TInput) K, valFn func(TInput) V, q IQueryable[TInput], opts ...MapOption[V]) (map[K]V, error) {
+	options := &mapOptions[V]{}
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	outputChan := q.Run(ctx)
+	output := map[K]V{}
+
+	for data := range outputChan {
+		key := keyFn(data)
+		value := valFn(data)
+
+		if existing, ok := output[key]; ok {
+			if options.merge == nil {
+				return nil, errors.Errorf("goql: duplicate key %v in ToMap", key)
+			}
+			value = options.merge(existing, value)
+		}
+
+		output[key] = value
+	}
+
+	if err := q.Wait(ctx); err != nil {
+		return nil, err
+	}
+
+	return output, nil
+}
+
+// cancelOnceFound drains q, calling visit for each item until it returns true
+// or the output closes. As soon as visit returns true, q's pipeline is
+// cancelled instead of drained to completion, since the caller's answer is
+// already known.
+func cancelOnceFound[T any](ctx context.Context, q IQueryable[T], visit func(T) bool) (bool, error) {
+	innerCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	outputChan := q.Run(innerCtx)
+
+	found := false
+	for item := range outputChan {
+		if found {
+			continue
+		}
+		if visit(item) {
+			found = true
+			cancel()
+		}
+	}
+
+	err := q.Wait(ctx)
+	if found && errors.Is(err, context.Canceled) {
+		err = nil
+	}
+
+	return found, err
+}
+
+// First returns the first element of q matching pred, cancelling the
+// pipeline as soon as it's found rather than draining the rest of q.
+func First[T any](ctx context.Context, pred func(T) bool, q IQueryable[T]) (result T, ok bool, err error) {
+	ok, err = cancelOnceFound(ctx, q, func(item T) bool {
+		if pred(item) {
+			result = item
+			return true
+		}
+		return false
+	})
+	return result, ok, err
+}
+
+// Any reports whether any element of q matches pred, cancelling the pipeline
+// as soon as a match is found.
+func Any[T any](ctx context.Context, pred func(T) bool, q IQueryable[T]) (bool, error) {
+	return cancelOnceFound(ctx, q, pred)
+}
+
+// All reports whether every element of q matches pred, cancelling the
+// pipeline as soon as a counterexample is found.
+func All[T any](ctx context.Context, pred func(T) bool, q IQueryable[T]) (bool, error) {
+	violated, err := cancelOnceFound(ctx, q, func(item T) bool { return !pred(item) })
+	return !violated, err
+}
+
+// Single returns the one element of q matching pred, cancelling the pipeline
+// as soon as a second match proves the result isn't unique. It errors if zero
+// or more than one element matches.
+func Single[T any](ctx context.Context, pred func(T) bool, q IQueryable[T]) (T, error) {
+	var result T
+	count := 0
+
+	tooMany, err := cancelOnceFound(ctx, q, func(item T) bool {
+		if !pred(item) {
+			return false
+		}
+		count++
+		if count == 1 {
+			result = item
+		}
+		return count > 1
+	})
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	if tooMany {
+		var zero T
+		return zero, errors.Errorf("goql: Single matched more than one element")
+	}
+	if count == 0 {
+		var zero T
+		return zero, errors.Errorf("goql: Single matched no elements")
+	}
+
+	return result, nil
+}
+
+// Count drains q and returns how many elements it produced.
+func Count[T any](ctx context.Context, q IQueryable[T]) (int, error) {
+	outputChan := q.Run(ctx)
+	count := 0
+
+	for range outputChan {
+		count++
+	}
+
+	if err := q.Wait(ctx); err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
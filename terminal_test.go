@@ -0,0 +1,152 @@
+package goql
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQueryable_ToSet(t *testing.T) {
+	ctx := context.Background()
+	inputData := []int{1, 2, 2, 3}
+
+	output, err := ToSet[int](ctx, FromArray(inputData).WithThreads(1))
+
+	assert.Nil(t, err)
+	assert.Equal(t, map[int]struct{}{1: {}, 2: {}, 3: {}}, output)
+}
+
+func TestQueryable_ToChannel(t *testing.T) {
+	ctx := context.Background()
+	inputData := []int{1, 2, 3}
+
+	dataChan, errChan := ToChannel[int](ctx, FromArray(inputData).WithThreads(1))
+
+	output := []int{}
+	for item := range dataChan {
+		output = append(output, item)
+	}
+
+	assert.Equal(t, inputData, output)
+	assert.Nil(t, <-errChan)
+}
+
+func TestQueryable_ToMap(t *testing.T) {
+	ctx := context.Background()
+	inputData := []int{1, 2, 3}
+
+	output, err := ToMap(ctx,
+		func(i int) int { return i },
+		func(i int) string { return "v" + string(rune('0'+i)) },
+		FromArray(inputData).WithThreads(1))
+
+	assert.Nil(t, err)
+	assert.Equal(t, map[int]string{1: "v1", 2: "v2", 3: "v3"}, output)
+}
+
+func TestQueryable_ToMap_DuplicateKeyError(t *testing.T) {
+	ctx := context.Background()
+	inputData := []int{1, 1, 2}
+
+	_, err := ToMap(ctx,
+		func(i int) int { return i % 2 },
+		func(i int) int { return i },
+		FromArray(inputData).WithThreads(1))
+
+	assert.NotNil(t, err)
+}
+
+func TestQueryable_ToMap_WithMerge(t *testing.T) {
+	ctx := context.Background()
+	inputData := []int{1, 1, 2}
+
+	output, err := ToMap(ctx,
+		func(i int) int { return i % 2 },
+		func(i int) int { return i },
+		FromArray(inputData).WithThreads(1),
+		WithMerge(func(existing, next int) int { return existing + next }))
+
+	assert.Nil(t, err)
+	assert.Equal(t, map[int]int{1: 2, 0: 2}, output)
+}
+
+func TestQueryable_First(t *testing.T) {
+	ctx := context.Background()
+	inputData := []int{1, 2, 3, 4, 5}
+
+	result, ok, err := First(ctx, func(i int) bool { return i%2 == 0 }, FromArray(inputData).WithThreads(1))
+
+	assert.Nil(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, 2, result)
+}
+
+func TestQueryable_First_NotFound(t *testing.T) {
+	ctx := context.Background()
+	inputData := []int{1, 3, 5}
+
+	_, ok, err := First(ctx, func(i int) bool { return i%2 == 0 }, FromArray(inputData).WithThreads(1))
+
+	assert.Nil(t, err)
+	assert.False(t, ok)
+}
+
+func TestQueryable_Single(t *testing.T) {
+	ctx := context.Background()
+	inputData := []int{1, 2, 3, 4, 5}
+
+	result, err := Single(ctx, func(i int) bool { return i == 3 }, FromArray(inputData).WithThreads(1))
+
+	assert.Nil(t, err)
+	assert.Equal(t, 3, result)
+}
+
+func TestQueryable_Single_MoreThanOneError(t *testing.T) {
+	ctx := context.Background()
+	inputData := []int{1, 2, 3, 4}
+
+	_, err := Single(ctx, func(i int) bool { return i%2 == 0 }, FromArray(inputData).WithThreads(1))
+
+	assert.NotNil(t, err)
+}
+
+func TestQueryable_Count(t *testing.T) {
+	ctx := context.Background()
+	inputData := []int{1, 2, 3, 4, 5}
+
+	count, err := Count[int](ctx, FromArray(inputData).WithThreads(1).Where(func(i int) bool { return i%2 == 0 }))
+
+	assert.Nil(t, err)
+	assert.Equal(t, 2, count)
+}
+
+func TestQueryable_Any(t *testing.T) {
+	ctx := context.Background()
+	inputData := []int{1, 2, 3}
+
+	ok, err := Any(ctx, func(i int) bool { return i == 2 }, FromArray(inputData).WithThreads(1))
+
+	assert.Nil(t, err)
+	assert.True(t, ok)
+}
+
+func TestQueryable_All(t *testing.T) {
+	ctx := context.Background()
+	inputData := []int{2, 4, 6}
+
+	ok, err := All(ctx, func(i int) bool { return i%2 == 0 }, FromArray(inputData).WithThreads(1))
+
+	assert.Nil(t, err)
+	assert.True(t, ok)
+}
+
+func TestQueryable_All_False(t *testing.T) {
+	ctx := context.Background()
+	inputData := []int{2, 3, 6}
+
+	ok, err := All(ctx, func(i int) bool { return i%2 == 0 }, FromArray(inputData).WithThreads(1))
+
+	assert.Nil(t, err)
+	assert.False(t, ok)
+}